@@ -0,0 +1,26 @@
+package spotify
+
+// SimpleAlbum contains basic information about an album, as returned when
+// browsing the catalog (e.g. new releases or search results). It omits
+// copyrights, external IDs, genres, and tracks.
+type SimpleAlbum struct {
+	Name                 string         `json:"name"`
+	ID                   ID             `json:"id"`
+	URI                  URI            `json:"uri"`
+	Endpoint             string         `json:"href"`
+	AlbumType            string         `json:"album_type"`
+	Artists              []SimpleArtist `json:"artists"`
+	Images               []Image        `json:"images"`
+	ReleaseDate          string         `json:"release_date"`
+	ReleaseDatePrecision string         `json:"release_date_precision"`
+	AvailableMarkets     []string       `json:"available_markets"`
+}
+
+// SimpleAlbumPage contains SimpleAlbums returned by a Spotify paging
+// endpoint, along with the paging metadata needed to walk further pages.
+type SimpleAlbumPage struct {
+	basePage
+	Albums []SimpleAlbum `json:"items"`
+}
+
+func (p SimpleAlbumPage) items() []SimpleAlbum { return p.Albums }
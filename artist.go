@@ -0,0 +1,30 @@
+package spotify
+
+// SimpleArtist contains basic information about an artist, as returned by
+// many endpoints that reference an artist without fetching its full
+// profile (genres, popularity, followers).
+type SimpleArtist struct {
+	Name     string `json:"name"`
+	ID       ID     `json:"id"`
+	URI      URI    `json:"uri"`
+	Endpoint string `json:"href"`
+}
+
+// FullArtist provides extra artist data on top of SimpleArtist, such as
+// genres, popularity, and follower counts.
+type FullArtist struct {
+	SimpleArtist
+	Genres     []string  `json:"genres"`
+	Images     []Image   `json:"images"`
+	Popularity int       `json:"popularity"`
+	Followers  Followers `json:"followers"`
+}
+
+// FullArtistPage contains FullArtists returned by a Spotify paging
+// endpoint, along with the paging metadata needed to walk further pages.
+type FullArtistPage struct {
+	basePage
+	Artists []FullArtist `json:"items"`
+}
+
+func (p FullArtistPage) items() []FullArtist { return p.Artists }
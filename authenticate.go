@@ -0,0 +1,178 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// AuthURL is the URL to Spotify Accounts Service's OAuth2 authorization endpoint.
+	AuthURL = "https://accounts.spotify.com/authorize"
+	// TokenURL is the URL to the Spotify Accounts Service's OAuth2 token endpoint.
+	TokenURL = "https://accounts.spotify.com/api/token"
+)
+
+// authFlow identifies which OAuth2 flow produced a Client.
+type authFlow int
+
+const (
+	userAuthFlow authFlow = iota
+	clientCredentialsFlow
+)
+
+// errRequiresUserAuth is returned by endpoints that act on a user's data
+// when called on a Client created via NewAppClient instead of
+// Authenticator.NewClient.
+var errRequiresUserAuth = errors.New("spotify: this endpoint requires a user-authorized client (see Authenticator.NewClient), not an app-only client")
+
+// requireUserAuth returns errRequiresUserAuth if c was not created through
+// the user authorization code flow.
+func (c *Client) requireUserAuth() error {
+	if c.flow == clientCredentialsFlow {
+		return errRequiresUserAuth
+	}
+	return nil
+}
+
+// Authenticator provides convenience functions for implementing the OAuth2
+// authorization code flow, which user-facing applications use to obtain a
+// Client that acts on a user's behalf.
+type Authenticator struct {
+	config *oauth2.Config
+}
+
+// NewAuthenticator creates an Authenticator that redirects users to
+// redirectURL after they grant (or deny) access on Spotify's site.
+// Additional OAuth2 scopes needed by the calling application can be
+// specified with scopes.
+func NewAuthenticator(redirectURL string, scopes ...string) Authenticator {
+	return Authenticator{
+		config: &oauth2.Config{
+			RedirectURL: redirectURL,
+			Scopes:      scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  AuthURL,
+				TokenURL: TokenURL,
+			},
+		},
+	}
+}
+
+// SetAuthInfo sets the client ID and secret used to exchange an
+// authorization code for a token.
+func (a *Authenticator) SetAuthInfo(clientID, secretKey string) {
+	a.config.ClientID = clientID
+	a.config.ClientSecret = secretKey
+}
+
+// AuthURL returns a URL to Spotify's OAuth2 authorization endpoint, for use
+// in the authorization code flow.
+func (a Authenticator) AuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return a.config.AuthCodeURL(state, opts...)
+}
+
+// Token pulls an authorization code from an HTTP request and exchanges it
+// for an access token.
+func (a Authenticator) Token(state string, r *http.Request) (*oauth2.Token, error) {
+	values := r.URL.Query()
+	if e := values.Get("error"); e != "" {
+		return nil, errors.New("spotify: auth failed - " + e)
+	}
+	code := values.Get("code")
+	if code == "" {
+		return nil, errors.New("spotify: didn't get access code")
+	}
+	if actual := values.Get("state"); actual != state {
+		return nil, errors.New("spotify: redirect state parameter doesn't match")
+	}
+	return a.config.Exchange(context.Background(), code)
+}
+
+// NewClient creates a user-authorized Client using the specified OAuth2
+// token.
+func (a Authenticator) NewClient(token *oauth2.Token) *Client {
+	return &Client{
+		http:    a.config.Client(context.Background(), token),
+		baseURL: baseAddress,
+		flow:    userAuthFlow,
+		cache:   NewMemoryCache(20*time.Minute, 3*time.Minute),
+	}
+}
+
+// NewAppClient creates a Client authorized via the OAuth2 Client
+// Credentials flow, which grants access to catalog-only endpoints (search,
+// new releases, album/artist/track lookups) without a user's permission.
+// It is not suitable for endpoints that act on a user's data, such as
+// playlists or saved tracks; those require a Client created with
+// Authenticator.NewClient.
+func NewAppClient(clientID, clientSecret string) (*Client, error) {
+	config := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     TokenURL,
+	}
+	ctx := context.Background()
+	ts := config.TokenSource(ctx)
+	// Fail fast if the credentials are rejected, rather than deferring the
+	// error to the first API call.
+	if _, err := ts.Token(); err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{
+		Transport: &reauthTransport{
+			base:   &oauth2.Transport{Source: ts},
+			config: config,
+			ctx:    ctx,
+		},
+	}
+	return &Client{
+		http:    httpClient,
+		baseURL: baseAddress,
+		flow:    clientCredentialsFlow,
+		cache:   NewMemoryCache(20*time.Minute, 3*time.Minute),
+	}, nil
+}
+
+// reauthTransport retries a request once, with a newly fetched token,
+// when the server responds 401. This matters because oauth2.Transport's
+// own pre-emptive refresh only fires once a token's Expiry has passed; a
+// 401 for any other reason (revocation, clock skew) would otherwise be
+// retried with the exact same, still-cached token.
+type reauthTransport struct {
+	mu     sync.Mutex
+	base   *oauth2.Transport
+	config *clientcredentials.Config
+	ctx    context.Context
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+	if err := t.refresh(); err != nil {
+		return resp, err
+	}
+	return t.base.RoundTrip(req.Clone(req.Context()))
+}
+
+// refresh fetches a brand new token - bypassing whatever stale-but-not-
+// yet-expired one the transport was using - and installs it as the
+// Source that t.base (and therefore subsequent requests too) will use.
+func (t *reauthTransport) refresh() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ts := t.config.TokenSource(t.ctx)
+	if _, err := ts.Token(); err != nil {
+		return err
+	}
+	t.base.Source = ts
+	return nil
+}
@@ -0,0 +1,132 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// TestReauthTransportRefreshesTokenOn401 reproduces NewAppClient's transport
+// construction against fake token and API servers, and verifies that a 401
+// triggers a refresh whose new token actually reaches the retried request -
+// not just a disconnected token cache that nothing reads.
+func TestReauthTransportRefreshesTokenOn401(t *testing.T) {
+	tokensIssued := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokensIssued++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+			ExpiresIn   int    `json:"expires_in"`
+		}{
+			AccessToken: "token-" + string(rune('0'+tokensIssued)),
+			TokenType:   "bearer",
+			ExpiresIn:   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	var seenAuth []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seenAuth = append(seenAuth, auth)
+		if auth == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	config := &clientcredentials.Config{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		TokenURL:     tokenServer.URL,
+	}
+	ctx := context.Background()
+	ts := config.TokenSource(ctx)
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("initial token fetch: %v", err)
+	}
+	httpClient := &http.Client{
+		Transport: &reauthTransport{
+			base:   &oauth2.Transport{Source: ts},
+			config: config,
+			ctx:    ctx,
+		},
+	}
+
+	req, err := http.NewRequest("GET", apiServer.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want 200 after a retried request with a refreshed token", resp.StatusCode)
+	}
+	if len(seenAuth) != 2 {
+		t.Fatalf("api server saw %d requests, want 2 (original + retry)", len(seenAuth))
+	}
+	if seenAuth[0] != "Bearer token-1" {
+		t.Fatalf("first request auth: got %q, want %q", seenAuth[0], "Bearer token-1")
+	}
+	if seenAuth[1] == seenAuth[0] {
+		t.Fatal("retried request reused the same rejected token instead of a refreshed one")
+	}
+}
+
+// TestReauthTransportPassesThroughSuccess ensures a non-401 response is
+// returned unchanged, without forcing a token refresh.
+func TestReauthTransportPassesThroughSuccess(t *testing.T) {
+	tokensIssued := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokensIssued++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+			ExpiresIn   int    `json:"expires_in"`
+		}{AccessToken: "token-ok", TokenType: "bearer", ExpiresIn: 3600})
+	}))
+	defer tokenServer.Close()
+
+	requests := 0
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	config := &clientcredentials.Config{ClientID: "id", ClientSecret: "secret", TokenURL: tokenServer.URL}
+	ctx := context.Background()
+	ts := config.TokenSource(ctx)
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("initial token fetch: %v", err)
+	}
+	httpClient := &http.Client{Transport: &reauthTransport{base: &oauth2.Transport{Source: ts}, config: config, ctx: ctx}}
+
+	req, _ := http.NewRequest("GET", apiServer.URL, nil)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if requests != 1 {
+		t.Fatalf("api server got %d requests, want 1 (no retry on success)", requests)
+	}
+	if tokensIssued != 1 {
+		t.Fatalf("token server got %d requests, want 1 (no refresh on success)", tokensIssued)
+	}
+}
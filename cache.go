@@ -0,0 +1,147 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// CachedResponse holds a previously-seen response body together with the
+// validator (ETag) needed to make a conditional request for it again.
+type CachedResponse struct {
+	Etag   string
+	Result []byte
+}
+
+// CacheEvent identifies what happened when Client.get consulted its
+// ResponseCache, for use with CacheObserver.
+type CacheEvent int
+
+const (
+	// CacheMiss means no cached entry existed for the URL.
+	CacheMiss CacheEvent = iota
+	// CacheHit means a cached entry was used without contacting the server.
+	CacheHit
+	// CacheNotModified means the server confirmed (via HTTP 304) that the
+	// cached entry is still valid.
+	CacheNotModified
+	// CacheStale means a cached entry existed and had an ETag, but the
+	// server rejected the conditional (If-None-Match) request and
+	// returned a fresh 200 response instead of a 304 - i.e. the cached
+	// copy was out of date and had to be refetched.
+	CacheStale
+)
+
+// CacheObserver receives notifications about ResponseCache activity, so
+// callers can wire it into metrics systems such as Prometheus.
+type CacheObserver interface {
+	ObserveCache(event CacheEvent, url string)
+}
+
+// ResponseCache stores HTTP responses keyed by request URL, so that Client
+// can serve cached bodies or make conditional (ETag) requests instead of
+// re-fetching unchanged resources. Implementations must be safe for
+// concurrent use.
+type ResponseCache interface {
+	// Get returns the cached response for url, if any.
+	Get(url string) (*CachedResponse, bool)
+	// Set stores resp for url, expiring it after ttl.
+	Set(url string, resp *CachedResponse, ttl time.Duration)
+}
+
+// SetCache replaces c's ResponseCache. Passing NopCache{} disables caching
+// entirely, which is useful in tests and multi-tenant servers where a
+// process-global cache would leak between callers.
+func (c *Client) SetCache(rc ResponseCache) {
+	c.cache = rc
+}
+
+// SetCacheObserver registers an observer that is notified of cache hits,
+// misses, and 304 (not modified) responses.
+func (c *Client) SetCacheObserver(o CacheObserver) {
+	c.cacheObserver = o
+}
+
+func (c *Client) observeCache(event CacheEvent, url string) {
+	if c.cacheObserver != nil {
+		c.cacheObserver.ObserveCache(event, url)
+	}
+}
+
+// NopCache is a ResponseCache that never stores anything.
+type NopCache struct{}
+
+// Get always reports a miss.
+func (NopCache) Get(url string) (*CachedResponse, bool) { return nil, false }
+
+// Set is a no-op.
+func (NopCache) Set(url string, resp *CachedResponse, ttl time.Duration) {}
+
+// memoryCache is the default ResponseCache, backed by an in-process
+// expiring map. Unlike the previous package-level cache, one is created per
+// Client, so it can't leak state between tenants or tests.
+type memoryCache struct {
+	store *cache.Cache
+}
+
+// NewMemoryCache creates a ResponseCache backed by an in-process,
+// per-Client cache with the given default expiration and cleanup interval.
+func NewMemoryCache(defaultExpiration, cleanupInterval time.Duration) ResponseCache {
+	return &memoryCache{store: cache.New(defaultExpiration, cleanupInterval)}
+}
+
+func (m *memoryCache) Get(url string) (*CachedResponse, bool) {
+	v, found := m.store.Get(url)
+	if !found {
+		return nil, false
+	}
+	return v.(*CachedResponse), true
+}
+
+func (m *memoryCache) Set(url string, resp *CachedResponse, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	m.store.Set(url, resp, ttl)
+}
+
+// RedisCache is a ResponseCache backed by Redis, so that cached responses
+// can be shared across multiple instances of an application.
+type RedisCache struct {
+	client *goredis.Client
+	prefix string
+}
+
+// NewRedisCache creates a RedisCache that stores entries under keyPrefix
+// using the given Redis client.
+func NewRedisCache(client *goredis.Client, keyPrefix string) *RedisCache {
+	return &RedisCache{client: client, prefix: keyPrefix}
+}
+
+// Get returns the cached response for url, if any.
+func (r *RedisCache) Get(url string) (*CachedResponse, bool) {
+	raw, err := r.client.Get(context.Background(), r.prefix+url).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	var resp CachedResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+// Set stores resp for url, expiring it after ttl.
+func (r *RedisCache) Set(url string, resp *CachedResponse, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	r.client.Set(context.Background(), r.prefix+url, raw, ttl)
+}
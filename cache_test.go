@@ -0,0 +1,146 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	events []CacheEvent
+}
+
+func (o *recordingObserver) ObserveCache(event CacheEvent, url string) {
+	o.events = append(o.events, event)
+}
+
+func TestGetCachesAndServesFromCacheControl(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(`{"name": "first"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.cache = NewMemoryCache(time.Minute, time.Minute)
+	obs := &recordingObserver{}
+	c.SetCacheObserver(obs)
+
+	var first, second struct{ Name string }
+	if err := c.get(context.Background(), server.URL, &first); err != nil {
+		t.Fatalf("first get: %v", err)
+	}
+	if err := c.get(context.Background(), server.URL, &second); err != nil {
+		t.Fatalf("second get: %v", err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("server got %d requests, want 1 (second call should be served from cache)", requests)
+	}
+	if second.Name != "first" {
+		t.Fatalf("got %q, want %q", second.Name, "first")
+	}
+	if len(obs.events) != 2 || obs.events[0] != CacheMiss || obs.events[1] != CacheHit {
+		t.Fatalf("got events %v, want [CacheMiss CacheHit]", obs.events)
+	}
+}
+
+func TestGetConditionalRequestHandles304(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == "v1" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", "v1")
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(`{"name": "first"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.cache = NewMemoryCache(time.Minute, time.Minute)
+	obs := &recordingObserver{}
+	c.SetCacheObserver(obs)
+
+	var first, second struct{ Name string }
+	if err := c.get(context.Background(), server.URL, &first); err != nil {
+		t.Fatalf("first get: %v", err)
+	}
+	if err := c.get(context.Background(), server.URL, &second); err != nil {
+		t.Fatalf("second get: %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("server got %d requests, want 2 (an ETag entry must be revalidated)", requests)
+	}
+	if second.Name != "first" {
+		t.Fatalf("got %q, want %q (304 body should come from the cache)", second.Name, "first")
+	}
+	if len(obs.events) != 2 || obs.events[0] != CacheMiss || obs.events[1] != CacheNotModified {
+		t.Fatalf("got events %v, want [CacheMiss CacheNotModified]", obs.events)
+	}
+}
+
+func TestGetConditionalRequestEmitsCacheStale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Always returns a fresh 200, even when If-None-Match is sent,
+		// simulating a cache entry that's gone stale server-side.
+		w.Header().Set("ETag", "v1")
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(`{"name": "fresh"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	c.cache = NewMemoryCache(time.Minute, time.Minute)
+	obs := &recordingObserver{}
+	c.SetCacheObserver(obs)
+
+	var first, second struct{ Name string }
+	if err := c.get(context.Background(), server.URL, &first); err != nil {
+		t.Fatalf("first get: %v", err)
+	}
+	if err := c.get(context.Background(), server.URL, &second); err != nil {
+		t.Fatalf("second get: %v", err)
+	}
+
+	if len(obs.events) != 2 || obs.events[0] != CacheMiss || obs.events[1] != CacheStale {
+		t.Fatalf("got events %v, want [CacheMiss CacheStale]", obs.events)
+	}
+}
+
+func TestCacheResponseHonorsMaxAge(t *testing.T) {
+	c := &Client{cache: NewMemoryCache(time.Minute, time.Minute)}
+	resp := &http.Response{Header: http.Header{
+		"Cache-Control": []string{"max-age=60"},
+		"ETag":          []string{"abc"},
+	}}
+	body := []byte(`{"ok":true}`)
+
+	c.cacheResponse(resp, "http://example.com/x", body)
+
+	cached, found := c.cache.Get("http://example.com/x")
+	if !found {
+		t.Fatal("expected a cache entry for a max-age response")
+	}
+	if cached.Etag != "abc" || string(cached.Result) != string(body) {
+		t.Fatalf("got %+v, want Etag=abc Result=%s", cached, body)
+	}
+}
+
+func TestCacheResponseSkipsUncacheableResponse(t *testing.T) {
+	c := &Client{cache: NewMemoryCache(time.Minute, time.Minute)}
+	resp := &http.Response{Header: http.Header{}}
+
+	c.cacheResponse(resp, "http://example.com/y", []byte(`{}`))
+
+	if _, found := c.cache.Get("http://example.com/y"); found {
+		t.Fatal("expected no cache entry for a response with no ETag, Last-Modified, or Expires")
+	}
+}
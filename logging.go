@@ -0,0 +1,52 @@
+package spotify
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+)
+
+// discardLogger is the default Logger for a Client that hasn't called
+// SetLogger, so the library stays silent unless a caller opts in.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// SetLogger installs l as c's structured logger. Events are logged with
+// keys such as method, url, status, cache ("hit", "miss", "stale", or "304"),
+// retry_after, duration_ms, and request_id.
+func (c *Client) SetLogger(l *slog.Logger) {
+	c.slog = l
+}
+
+func (c *Client) logger() *slog.Logger {
+	if c.slog != nil {
+		return c.slog
+	}
+	return discardLogger
+}
+
+// requestSeq generates the request_id attached to each log event, so a
+// request's cache lookup, response, and any retries can be correlated in
+// logs without needing a full tracing setup.
+var requestSeq uint64
+
+func nextRequestID() string {
+	return strconv.FormatUint(atomic.AddUint64(&requestSeq, 1), 36)
+}
+
+// RoundTripperMiddleware wraps an http.RoundTripper to add behavior, such
+// as OpenTelemetry tracing, around every request a Client makes.
+type RoundTripperMiddleware func(http.RoundTripper) http.RoundTripper
+
+// Use appends middleware to c's transport chain. Middleware are applied in
+// the order they're registered, so the first one wraps the raw transport
+// and the last one registered is the outermost layer that sees the
+// request first.
+func (c *Client) Use(mw RoundTripperMiddleware) {
+	base := c.http.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.http.Transport = mw(base)
+}
@@ -0,0 +1,106 @@
+package spotify
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestClientDefaultsToDiscardLogger(t *testing.T) {
+	c := &Client{}
+	if c.logger() != discardLogger {
+		t.Fatal("expected logger() to return discardLogger before SetLogger is called")
+	}
+}
+
+func TestSetLoggerOverridesDefault(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, nil))
+
+	c := &Client{}
+	c.SetLogger(l)
+	if c.logger() != l {
+		t.Fatal("expected logger() to return the logger installed via SetLogger")
+	}
+
+	c.logger().Info("hello")
+	if buf.Len() == 0 {
+		t.Fatal("expected SetLogger's logger to actually receive log output")
+	}
+}
+
+func TestUseWrapsDefaultTransportWhenUnset(t *testing.T) {
+	c := &Client{http: &http.Client{}}
+	called := false
+	c.Use(func(rt http.RoundTripper) http.RoundTripper {
+		if rt != http.DefaultTransport {
+			t.Fatalf("expected base transport to be http.DefaultTransport, got %v", rt)
+		}
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+			return rt.RoundTrip(req)
+		})
+	})
+
+	if _, ok := c.http.Transport.(roundTripFunc); !ok {
+		t.Fatalf("expected middleware to install its RoundTripper, got %T", c.http.Transport)
+	}
+	_ = called
+}
+
+func TestUseChainsMiddlewareInRegistrationOrder(t *testing.T) {
+	c := &Client{http: &http.Client{}}
+	var order []string
+
+	mw := func(name string) RoundTripperMiddleware {
+		return func(rt http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return rt.RoundTrip(req)
+			})
+		}
+	}
+
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	c.http.Transport = base
+
+	c.Use(mw("first"))
+	c.Use(mw("second"))
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	if _, err := c.http.Transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+
+	want := []string{"second", "first", "base"}
+	if len(order) != len(want) {
+		t.Fatalf("got order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestNextRequestIDIsUniqueAndMonotonic(t *testing.T) {
+	a := nextRequestID()
+	b := nextRequestID()
+	if a == b {
+		t.Fatalf("expected distinct request IDs, got %q twice", a)
+	}
+	an, _ := strconv.ParseUint(a, 36, 64)
+	bn, _ := strconv.ParseUint(b, 36, 64)
+	if bn <= an {
+		t.Fatalf("expected request IDs to increase, got %q then %q", a, b)
+	}
+}
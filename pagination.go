@@ -0,0 +1,131 @@
+package spotify
+
+import "context"
+
+// Page is implemented by Spotify's paging objects (SimpleAlbumPage,
+// FullTrackPage, PlaylistTrackPage, and the other *Page types), letting Paginate walk them generically without knowing
+// the concrete item type ahead of time. Embed basePage in a paging object
+// and add an `items` method to satisfy it.
+type Page[T any] interface {
+	items() []T
+	offset() int
+	limit() int
+	total() int
+}
+
+// basePage holds the paging metadata common to every Spotify paging
+// object.
+type basePage struct {
+	Endpoint string `json:"href"`
+	Limit    int    `json:"limit"`
+	NextURL  string `json:"next"`
+	Offset   int    `json:"offset"`
+	Previous string `json:"previous"`
+	Total    int    `json:"total"`
+}
+
+func (p basePage) offset() int { return p.Offset }
+func (p basePage) limit() int  { return p.Limit }
+func (p basePage) total() int  { return p.Total }
+
+// PageResult is sent down the channel returned by Paginate: either a page
+// of items, or the error that stopped pagination. Callers should check Err
+// on each result: an error result is the last one sent before the channel
+// closes, while a clean finish closes the channel without one.
+type PageResult[T any] struct {
+	Items []T
+	Err   error
+}
+
+// FetchPageFunc fetches the page of results at the given offset. It is
+// typically a closure over a Client's paged endpoint, such as
+// Client.NewReleasesOpt.
+type FetchPageFunc[T any, P Page[T]] func(ctx context.Context, offset int) (P, error)
+
+// Paginate walks a Spotify paging endpoint, starting from an
+// already-fetched first page, and streams every page's items down the
+// returned channel. Once the total item count is known (from first),
+// remaining pages are fetched concurrently by up to workers goroutines,
+// but are still delivered on the channel in page order, so consumers can
+// range over it like a single flattened list. Cancelling ctx stops
+// in-flight fetches and closes the channel. AutoRetry on the underlying
+// Client still applies to each page's request, so 429s are handled as
+// usual.
+func Paginate[T any, P Page[T]](ctx context.Context, workers int, first P, fetch FetchPageFunc[T, P]) <-chan PageResult[T] {
+	if workers < 1 {
+		workers = 1
+	}
+	out := make(chan PageResult[T])
+
+	go func() {
+		defer close(out)
+
+		select {
+		case out <- PageResult[T]{Items: first.items()}:
+		case <-ctx.Done():
+			return
+		}
+
+		limit := first.limit()
+		total := first.total()
+		if limit <= 0 || first.offset()+limit >= total {
+			return
+		}
+
+		var offsets []int
+		for o := first.offset() + limit; o < total; o += limit {
+			offsets = append(offsets, o)
+		}
+
+		jobs := make(chan int)
+		slots := make([]chan PageResult[T], len(offsets))
+		for i := range slots {
+			slots[i] = make(chan PageResult[T], 1)
+		}
+
+		go func() {
+			defer close(jobs)
+			for i := range offsets {
+				select {
+				case jobs <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		for w := 0; w < workers; w++ {
+			go func() {
+				for i := range jobs {
+					page, err := fetch(ctx, offsets[i])
+					if err != nil {
+						slots[i] <- PageResult[T]{Err: err}
+						continue
+					}
+					slots[i] <- PageResult[T]{Items: page.items()}
+				}
+			}()
+		}
+
+		for _, slot := range slots {
+			select {
+			case res := <-slot:
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case out <- res:
+				case <-ctx.Done():
+					return
+				}
+				if res.Err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
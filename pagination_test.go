@@ -0,0 +1,124 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+)
+
+type intPage struct {
+	basePage
+	Items []int
+}
+
+func (p intPage) items() []int { return p.Items }
+
+func newIntPage(offset, limit, total int, items []int) intPage {
+	return intPage{basePage: basePage{Offset: offset, Limit: limit, Total: total}, Items: items}
+}
+
+func TestPaginateOrdersPagesByOffset(t *testing.T) {
+	const limit = 2
+	const total = 10
+	all := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	first := newIntPage(0, limit, total, all[0:limit])
+
+	fetch := func(ctx context.Context, offset int) (intPage, error) {
+		end := offset + limit
+		if end > total {
+			end = total
+		}
+		return newIntPage(offset, limit, total, all[offset:end]), nil
+	}
+
+	var got []int
+	for res := range Paginate[int](context.Background(), 4, first, fetch) {
+		if res.Err != nil {
+			t.Fatalf("unexpected error: %v", res.Err)
+		}
+		got = append(got, res.Items...)
+	}
+
+	if len(got) != len(all) {
+		t.Fatalf("got %d items, want %d", len(got), len(all))
+	}
+	for i, v := range got {
+		if v != all[i] {
+			t.Fatalf("items out of order: got %v, want %v", got, all)
+		}
+	}
+}
+
+func TestPaginateStopsOnFirstError(t *testing.T) {
+	const limit = 1
+	const total = 5
+	first := newIntPage(0, limit, total, []int{0})
+
+	failAt := 3
+	fetch := func(ctx context.Context, offset int) (intPage, error) {
+		if offset == failAt {
+			return intPage{}, errors.New("boom")
+		}
+		return newIntPage(offset, limit, total, []int{offset}), nil
+	}
+
+	var sawErr bool
+	var gotOffsets []int
+	for res := range Paginate[int](context.Background(), 2, first, fetch) {
+		if res.Err != nil {
+			sawErr = true
+			break
+		}
+		gotOffsets = append(gotOffsets, res.Items...)
+	}
+
+	if !sawErr {
+		t.Fatal("expected an error result before the channel closed")
+	}
+	sort.Ints(gotOffsets)
+	for _, o := range gotOffsets {
+		if o >= failAt {
+			t.Fatalf("received page at or after the failing offset: %v", gotOffsets)
+		}
+	}
+}
+
+func TestPaginateStopsOnCancellation(t *testing.T) {
+	const limit = 1
+	const total = 1000
+	first := newIntPage(0, limit, total, []int{0})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fetch := func(ctx context.Context, offset int) (intPage, error) {
+		return newIntPage(offset, limit, total, []int{offset}), nil
+	}
+
+	count := 0
+	for range Paginate[int](ctx, 4, first, fetch) {
+		count++
+	}
+
+	if count > 1 {
+		t.Fatalf("expected pagination to stop quickly after cancellation, got %d pages", count)
+	}
+}
+
+func TestPaginateSinglePageSkipsFetch(t *testing.T) {
+	first := newIntPage(0, 10, 3, []int{0, 1, 2})
+
+	fetch := func(ctx context.Context, offset int) (intPage, error) {
+		t.Fatalf("fetch should not be called when the first page covers the whole total")
+		return intPage{}, nil
+	}
+
+	var got []int
+	for res := range Paginate[int](context.Background(), 4, first, fetch) {
+		got = append(got, res.Items...)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d items, want 3", len(got))
+	}
+}
@@ -0,0 +1,367 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// maxPlaylistTracksPerRequest is the maximum number of tracks Spotify
+// accepts in a single add/remove-tracks request.
+const maxPlaylistTracksPerRequest = 100
+
+// SimpleTrack contains basic information about a track, as returned when
+// browsing a playlist or album without fetching its full audio features
+// and popularity.
+type SimpleTrack struct {
+	Name        string         `json:"name"`
+	ID          ID             `json:"id"`
+	URI         URI            `json:"uri"`
+	Endpoint    string         `json:"href"`
+	Artists     []SimpleArtist `json:"artists"`
+	DiscNumber  int            `json:"disc_number"`
+	Duration    int            `json:"duration_ms"`
+	Explicit    bool           `json:"explicit"`
+	TrackNumber int            `json:"track_number"`
+}
+
+// PlaylistTrack contains information about a track's place within a
+// playlist, as opposed to a track returned in any other context.
+type PlaylistTrack struct {
+	AddedAt string      `json:"added_at"`
+	Track   SimpleTrack `json:"track"`
+}
+
+// PlaylistTrackPage contains PlaylistTracks returned by a Spotify paging
+// endpoint, along with the paging metadata needed to walk further pages.
+type PlaylistTrackPage struct {
+	basePage
+	Tracks []PlaylistTrack `json:"items"`
+}
+
+func (p PlaylistTrackPage) items() []PlaylistTrack { return p.Tracks }
+
+// playlistOwner identifies the user that owns a playlist.
+type playlistOwner struct {
+	ID ID `json:"id"`
+}
+
+// FullPlaylist contains detailed information about a playlist, including
+// its snapshot ID and first page of tracks, as returned by
+// CreatePlaylistForUser and GetPlaylist.
+type FullPlaylist struct {
+	Name       string            `json:"name"`
+	ID         ID                `json:"id"`
+	URI        URI               `json:"uri"`
+	Endpoint   string            `json:"href"`
+	Owner      playlistOwner     `json:"owner"`
+	Public     bool              `json:"public"`
+	SnapshotID string            `json:"snapshot_id"`
+	Tracks     PlaylistTrackPage `json:"tracks"`
+}
+
+// SimplePlaylist contains basic information about a playlist, as returned
+// when browsing or searching without fetching its full track listing.
+type SimplePlaylist struct {
+	Name       string        `json:"name"`
+	ID         ID            `json:"id"`
+	URI        URI           `json:"uri"`
+	Endpoint   string        `json:"href"`
+	Owner      playlistOwner `json:"owner"`
+	Public     bool          `json:"public"`
+	SnapshotID string        `json:"snapshot_id"`
+}
+
+// SimplePlaylistPage contains SimplePlaylists returned by a Spotify
+// paging endpoint, along with the paging metadata needed to walk further
+// pages.
+type SimplePlaylistPage struct {
+	basePage
+	Playlists []SimplePlaylist `json:"items"`
+}
+
+func (p SimplePlaylistPage) items() []SimplePlaylist { return p.Playlists }
+
+// CreatePlaylistForUser creates a playlist owned by userID. The Client
+// must be user-authorized (via Authenticator.NewClient) with the
+// playlist-modify scope.
+func (c *Client) CreatePlaylistForUser(ctx context.Context, userID, name, description string, public bool) (*FullPlaylist, error) {
+	if err := c.requireUserAuth(); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Public      bool   `json:"public"`
+	}{name, description, public})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"users/"+userID+"/playlists", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result FullPlaylist
+	if err := c.execute(req, &result, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPlaylist fetches a playlist's metadata, including its current
+// snapshot ID, along with the first page of its tracks.
+func (c *Client) GetPlaylist(ctx context.Context, playlistID ID) (*FullPlaylist, error) {
+	var result FullPlaylist
+	if err := c.get(ctx, c.baseURL+"playlists/"+string(playlistID), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPlaylistTracks gets the first page of a playlist's tracks. Use
+// GetPlaylistTracksAll to walk every page.
+func (c *Client) GetPlaylistTracks(ctx context.Context, playlistID ID) (*PlaylistTrackPage, error) {
+	var result PlaylistTrackPage
+	if err := c.get(ctx, c.baseURL+"playlists/"+string(playlistID)+"/tracks", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPlaylistTracksAll is like GetPlaylistTracks, but it walks every page
+// of the playlist's tracks, prefetching up to workers pages concurrently
+// and streaming them down the returned channel in order. See Paginate for
+// cancellation and error-handling semantics.
+func (c *Client) GetPlaylistTracksAll(ctx context.Context, playlistID ID, workers int) <-chan PageResult[PlaylistTrack] {
+	first, err := c.GetPlaylistTracks(ctx, playlistID)
+	if err != nil {
+		out := make(chan PageResult[PlaylistTrack], 1)
+		out <- PageResult[PlaylistTrack]{Err: err}
+		close(out)
+		return out
+	}
+	return Paginate[PlaylistTrack](ctx, workers, *first, func(ctx context.Context, offset int) (PlaylistTrackPage, error) {
+		var page PlaylistTrackPage
+		spotifyURL := c.baseURL + "playlists/" + string(playlistID) + "/tracks?offset=" + strconv.Itoa(offset)
+		if err := c.get(ctx, spotifyURL, &page); err != nil {
+			return PlaylistTrackPage{}, err
+		}
+		return page, nil
+	})
+}
+
+// AddTracksToPlaylist appends trackURIs to a playlist in a single
+// request and returns the playlist's new snapshot ID. Spotify allows at
+// most 100 tracks per request; use PlaylistBuilder.ReplaceTracks to add
+// more than that.
+func (c *Client) AddTracksToPlaylist(ctx context.Context, playlistID ID, trackURIs ...URI) (snapshotID string, err error) {
+	if err := c.requireUserAuth(); err != nil {
+		return "", err
+	}
+	if len(trackURIs) > maxPlaylistTracksPerRequest {
+		return "", fmt.Errorf("spotify: can't add more than %d tracks in one request", maxPlaylistTracksPerRequest)
+	}
+	body, err := json.Marshal(struct {
+		URIs []URI `json:"uris"`
+	}{trackURIs})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"playlists/"+string(playlistID)+"/tracks", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		SnapshotID string `json:"snapshot_id"`
+	}
+	if err := c.execute(req, &result, http.StatusCreated); err != nil {
+		return "", err
+	}
+	return result.SnapshotID, nil
+}
+
+// RemoveTracksFromPlaylist removes trackURIs from a playlist and returns
+// its new snapshot ID. If snapshotID is non-empty, the removal is scoped
+// to that snapshot: Spotify rejects the request with an error if the
+// playlist has since been modified, instead of silently operating on
+// stale data.
+func (c *Client) RemoveTracksFromPlaylist(ctx context.Context, playlistID ID, snapshotID string, trackURIs ...URI) (newSnapshotID string, err error) {
+	if err := c.requireUserAuth(); err != nil {
+		return "", err
+	}
+	if len(trackURIs) > maxPlaylistTracksPerRequest {
+		return "", fmt.Errorf("spotify: can't remove more than %d tracks in one request", maxPlaylistTracksPerRequest)
+	}
+	tracks := make([]struct {
+		URI URI `json:"uri"`
+	}, len(trackURIs))
+	for i, u := range trackURIs {
+		tracks[i].URI = u
+	}
+	body, err := json.Marshal(struct {
+		Tracks []struct {
+			URI URI `json:"uri"`
+		} `json:"tracks"`
+		SnapshotID string `json:"snapshot_id,omitempty"`
+	}{tracks, snapshotID})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.baseURL+"playlists/"+string(playlistID)+"/tracks", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		SnapshotID string `json:"snapshot_id"`
+	}
+	if err := c.execute(req, &result); err != nil {
+		return "", err
+	}
+	return result.SnapshotID, nil
+}
+
+// TrackDiff describes the changes PlaylistBuilder.ReplaceTracks would
+// make, or made, to a playlist.
+type TrackDiff struct {
+	ToAdd    []URI
+	ToRemove []URI
+}
+
+// PlaylistBuilder provides a high-level API for keeping a playlist's
+// tracks in sync with a desired set of URIs. It wraps AddTracksToPlaylist
+// and RemoveTracksFromPlaylist with automatic 100-track chunking, duplicate
+// detection, and removals scoped to the playlist's snapshot ID. It does not
+// create playlists; CreatePlaylistForUser does that.
+type PlaylistBuilder struct {
+	c *Client
+
+	// DryRun, when true, makes ReplaceTracks compute and return the
+	// planned diff without mutating the playlist.
+	DryRun bool
+}
+
+// NewPlaylistBuilder creates a PlaylistBuilder that operates through c.
+func NewPlaylistBuilder(c *Client) *PlaylistBuilder {
+	return &PlaylistBuilder{c: c}
+}
+
+// ReplaceTracks makes the set of tracks in playlistID match target,
+// de-duplicating target and skipping tracks it already contains. Order is
+// ignored, and a track that target keeps stays in the playlist as many
+// times as it already appears there. The
+// operation is idempotent: calling it again with the same target is a
+// no-op. If b.DryRun is set, no changes are made and the returned
+// TrackDiff describes what would have happened.
+func (b *PlaylistBuilder) ReplaceTracks(ctx context.Context, playlistID ID, target []URI) (*TrackDiff, error) {
+	current, snapshotID, err := b.currentTracks(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffTracks(current, target)
+
+	if b.DryRun || (len(diff.ToAdd) == 0 && len(diff.ToRemove) == 0) {
+		return diff, nil
+	}
+
+	for _, chunk := range chunkURIs(diff.ToRemove, maxPlaylistTracksPerRequest) {
+		if err := ctx.Err(); err != nil {
+			return diff, err
+		}
+		next, err := b.c.RemoveTracksFromPlaylist(ctx, playlistID, snapshotID, chunk...)
+		if err != nil {
+			return diff, err
+		}
+		snapshotID = next
+	}
+	for _, chunk := range chunkURIs(diff.ToAdd, maxPlaylistTracksPerRequest) {
+		if err := ctx.Err(); err != nil {
+			return diff, err
+		}
+		next, err := b.c.AddTracksToPlaylist(ctx, playlistID, chunk...)
+		if err != nil {
+			return diff, err
+		}
+		snapshotID = next
+	}
+
+	return diff, nil
+}
+
+func (b *PlaylistBuilder) currentTracks(ctx context.Context, playlistID ID) (uris []URI, snapshotID string, err error) {
+	playlist, err := b.c.GetPlaylist(ctx, playlistID)
+	if err != nil {
+		return nil, "", err
+	}
+	for page := range b.c.GetPlaylistTracksAll(ctx, playlistID, 4) {
+		if page.Err != nil {
+			return nil, "", page.Err
+		}
+		for _, t := range page.Items {
+			uris = append(uris, t.Track.URI)
+		}
+	}
+	return uris, playlist.SnapshotID, nil
+}
+
+// diffTracks computes the additions and removals needed to make current
+// match target, de-duplicating target and ignoring order.
+func diffTracks(current, target []URI) *TrackDiff {
+	wanted := dedupeURIs(target)
+	wantedSet := make(map[URI]bool, len(wanted))
+	for _, u := range wanted {
+		wantedSet[u] = true
+	}
+	currentSet := make(map[URI]bool, len(current))
+	for _, u := range current {
+		currentSet[u] = true
+	}
+
+	diff := &TrackDiff{}
+	for _, u := range current {
+		if !wantedSet[u] {
+			diff.ToRemove = append(diff.ToRemove, u)
+		}
+	}
+	for _, u := range wanted {
+		if !currentSet[u] {
+			diff.ToAdd = append(diff.ToAdd, u)
+		}
+	}
+	return diff
+}
+
+func dedupeURIs(uris []URI) []URI {
+	seen := make(map[URI]bool, len(uris))
+	out := make([]URI, 0, len(uris))
+	for _, u := range uris {
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		out = append(out, u)
+	}
+	return out
+}
+
+func chunkURIs(uris []URI, size int) [][]URI {
+	var chunks [][]URI
+	for len(uris) > 0 {
+		n := size
+		if n > len(uris) {
+			n = len(uris)
+		}
+		chunks = append(chunks, uris[:n])
+		uris = uris[n:]
+	}
+	return chunks
+}
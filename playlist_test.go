@@ -0,0 +1,94 @@
+package spotify
+
+import "testing"
+
+func uris(ss ...string) []URI {
+	out := make([]URI, len(ss))
+	for i, s := range ss {
+		out[i] = URI(s)
+	}
+	return out
+}
+
+func TestDedupeURIsPreservesFirstOccurrenceOrder(t *testing.T) {
+	got := dedupeURIs(uris("a", "b", "a", "c", "b"))
+	want := uris("a", "b", "c")
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDedupeURIsEmptyInput(t *testing.T) {
+	got := dedupeURIs(nil)
+	if len(got) != 0 {
+		t.Fatalf("got %v, want empty", got)
+	}
+}
+
+func TestChunkURIsSplitsIntoFixedSizeGroups(t *testing.T) {
+	chunks := chunkURIs(uris("a", "b", "c", "d", "e"), 2)
+	if len(chunks) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(chunks))
+	}
+	if len(chunks[0]) != 2 || len(chunks[1]) != 2 || len(chunks[2]) != 1 {
+		t.Fatalf("unexpected chunk sizes: %v", chunks)
+	}
+}
+
+func TestChunkURIsEmptyInput(t *testing.T) {
+	chunks := chunkURIs(nil, 100)
+	if len(chunks) != 0 {
+		t.Fatalf("got %v, want no chunks", chunks)
+	}
+}
+
+func TestChunkURIsExactMultipleOfSize(t *testing.T) {
+	chunks := chunkURIs(uris("a", "b", "c", "d"), 2)
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+}
+
+func TestDiffTracksAddsAndRemoves(t *testing.T) {
+	current := uris("a", "b", "c")
+	target := uris("b", "c", "d")
+
+	diff := diffTracks(current, target)
+
+	if len(diff.ToRemove) != 1 || diff.ToRemove[0] != URI("a") {
+		t.Fatalf("ToRemove: got %v, want [a]", diff.ToRemove)
+	}
+	if len(diff.ToAdd) != 1 || diff.ToAdd[0] != URI("d") {
+		t.Fatalf("ToAdd: got %v, want [d]", diff.ToAdd)
+	}
+}
+
+func TestDiffTracksDedupesTarget(t *testing.T) {
+	current := uris("a")
+	target := uris("a", "b", "b", "b")
+
+	diff := diffTracks(current, target)
+
+	if len(diff.ToAdd) != 1 || diff.ToAdd[0] != URI("b") {
+		t.Fatalf("ToAdd: got %v, want [b]", diff.ToAdd)
+	}
+	if len(diff.ToRemove) != 0 {
+		t.Fatalf("ToRemove: got %v, want none", diff.ToRemove)
+	}
+}
+
+func TestDiffTracksNoChangeWhenAlreadyEqual(t *testing.T) {
+	current := uris("a", "b", "c")
+	target := uris("c", "b", "a", "a")
+
+	diff := diffTracks(current, target)
+
+	if len(diff.ToAdd) != 0 || len(diff.ToRemove) != 0 {
+		t.Fatalf("got ToAdd=%v ToRemove=%v, want both empty", diff.ToAdd, diff.ToRemove)
+	}
+}
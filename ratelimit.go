@@ -0,0 +1,169 @@
+package spotify
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter applies a pre-emptive token-bucket delay before each
+// request, so a Client backs off before Spotify returns a 429 rather than
+// only reacting to one after the fact. One bucket is tracked per request
+// host, since a process may talk to both api.spotify.com and
+// accounts.spotify.com with very different limits.
+type RateLimiter struct {
+	rate  float64 // tokens replenished per second
+	burst float64 // bucket capacity
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter that allows up to burst requests
+// immediately and then replenishes at rate requests per second thereafter.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// wait blocks until a token is available for host, consuming it, and
+// returns the total duration it slept (zero if a token was already
+// available).
+func (rl *RateLimiter) wait(host string) time.Duration {
+	var waited time.Duration
+	for {
+		d := rl.reserve(host)
+		if d <= 0 {
+			return waited
+		}
+		time.Sleep(d)
+		waited += d
+	}
+}
+
+// reserve consumes a token for host if one is already available and
+// returns zero, or returns the duration to wait before one will be.
+func (rl *RateLimiter) reserve(host string) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	b, ok := rl.buckets[host]
+	if !ok {
+		b = &tokenBucket{tokens: rl.burst, last: now}
+		rl.buckets[host] = b
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * rl.rate
+		if b.tokens > rl.burst {
+			b.tokens = rl.burst
+		}
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+}
+
+// remaining reports each host's current estimated token count, without
+// consuming one.
+func (rl *RateLimiter) remaining() map[string]float64 {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	out := make(map[string]float64, len(rl.buckets))
+	for host, b := range rl.buckets {
+		out[host] = b.tokens
+	}
+	return out
+}
+
+// RateLimitStatus summarizes a Client's most recent interaction with
+// Spotify's rate limits.
+type RateLimitStatus struct {
+	// LastRetryAfter is the most recent rate-limit delay the Client waited
+	// out, whether a pre-emptive RateLimiter throttle or a reactive 429
+	// backoff, or zero if it hasn't waited on either yet.
+	LastRetryAfter time.Duration
+	// Remaining is the estimated token-bucket budget left per host. It's
+	// nil unless a RateLimiter has been installed with SetRateLimiter.
+	Remaining map[string]float64
+}
+
+// SetRateLimiter installs rl so that every outgoing request is throttled
+// pre-emptively, rather than only backing off after a 429. Pass nil to
+// disable pre-emptive throttling (the default).
+func (c *Client) SetRateLimiter(rl *RateLimiter) {
+	c.limiter = rl
+}
+
+// OnRateLimit registers fn to be called whenever c waits on a rate limit,
+// whether pre-emptively (a RateLimiter installed with SetRateLimiter) or
+// reactively (a 429 response), with the duration it waited. Long-running
+// bots can use it to log or otherwise back off gracefully.
+func (c *Client) OnRateLimit(fn func(time.Duration)) {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimitHooks = append(c.rateLimitHooks, fn)
+}
+
+// RateLimitStatus reports the most recent rate-limit delay c waited out,
+// pre-emptive or after a 429, and, if a RateLimiter is configured, its
+// remaining per-host token budget.
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	c.rateLimitMu.Lock()
+	last := c.lastRetryAfter
+	c.rateLimitMu.Unlock()
+
+	status := RateLimitStatus{LastRetryAfter: last}
+	if c.limiter != nil {
+		status.Remaining = c.limiter.remaining()
+	}
+	return status
+}
+
+func (c *Client) noteRateLimit(d time.Duration) {
+	c.rateLimitMu.Lock()
+	c.lastRetryAfter = d
+	hooks := make([]func(time.Duration), len(c.rateLimitHooks))
+	copy(hooks, c.rateLimitHooks)
+	c.rateLimitMu.Unlock()
+
+	for _, fn := range hooks {
+		fn(d)
+	}
+}
+
+// maxBackoffShift caps the exponential growth in retryDuration so a long
+// run of 429s can't overflow or sleep absurdly long.
+const maxBackoffShift = 6
+
+// retryDuration computes how long to wait before retrying a request that
+// received a 429 or 202, preferring the server's Retry-After header and
+// falling back to jittered exponential backoff seeded by
+// defaultRetryDuration when the header is absent.
+func retryDuration(resp *http.Response, attempt int) time.Duration {
+	if raw := resp.Header.Get("Retry-After"); raw != "" {
+		if seconds, err := strconv.ParseInt(raw, 10, 32); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	if attempt > maxBackoffShift {
+		attempt = maxBackoffShift
+	}
+	backoff := defaultRetryDuration << uint(attempt)
+	jitter := time.Duration(rand.Int63n(int64(backoff)))
+	return backoff/2 + jitter/2
+}
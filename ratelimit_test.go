@@ -0,0 +1,88 @@
+package spotify
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserveAllowsBurstThenThrottles(t *testing.T) {
+	rl := NewRateLimiter(1, 2)
+
+	if d := rl.reserve("api.spotify.com"); d != 0 {
+		t.Fatalf("first reserve in burst: got wait %v, want 0", d)
+	}
+	if d := rl.reserve("api.spotify.com"); d != 0 {
+		t.Fatalf("second reserve in burst: got wait %v, want 0", d)
+	}
+	if d := rl.reserve("api.spotify.com"); d <= 0 {
+		t.Fatalf("third reserve should exceed burst and require a wait, got %v", d)
+	}
+}
+
+func TestRateLimiterBucketsArePerHost(t *testing.T) {
+	rl := NewRateLimiter(1, 1)
+
+	if d := rl.reserve("api.spotify.com"); d != 0 {
+		t.Fatalf("api.spotify.com reserve: got wait %v, want 0", d)
+	}
+	if d := rl.reserve("accounts.spotify.com"); d != 0 {
+		t.Fatalf("accounts.spotify.com should have its own bucket, got wait %v", d)
+	}
+}
+
+func TestRateLimiterWaitReturnsTotalSleptDuration(t *testing.T) {
+	rl := NewRateLimiter(1000, 1)
+
+	if d := rl.wait("api.spotify.com"); d != 0 {
+		t.Fatalf("first wait should consume the burst token immediately, got %v", d)
+	}
+	d := rl.wait("api.spotify.com")
+	if d <= 0 {
+		t.Fatalf("second wait should report a nonzero delay, got %v", d)
+	}
+	if d > 50*time.Millisecond {
+		t.Fatalf("wait delay longer than expected for rate=1000/s: %v", d)
+	}
+}
+
+func TestRetryDurationPrefersRetryAfterHeader(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	got := retryDuration(resp, 0)
+	if got != 2*time.Second {
+		t.Fatalf("got %v, want 2s", got)
+	}
+}
+
+func TestRetryDurationFallsBackToJitteredBackoff(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+
+	for attempt := 0; attempt <= maxBackoffShift+2; attempt++ {
+		shift := attempt
+		if shift > maxBackoffShift {
+			shift = maxBackoffShift
+		}
+		backoff := defaultRetryDuration << uint(shift)
+		min := backoff / 2
+		max := backoff
+
+		for i := 0; i < 20; i++ {
+			got := retryDuration(resp, attempt)
+			if got < min || got > max {
+				t.Fatalf("attempt %d: got %v, want within [%v, %v]", attempt, got, min, max)
+			}
+		}
+	}
+}
+
+func TestRetryDurationCapsBackoffShift(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+
+	cappedBackoff := defaultRetryDuration << uint(maxBackoffShift)
+	for i := 0; i < 20; i++ {
+		got := retryDuration(resp, maxBackoffShift+10)
+		if got > cappedBackoff {
+			t.Fatalf("got %v, want capped at %v", got, cappedBackoff)
+		}
+	}
+}
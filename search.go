@@ -0,0 +1,173 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchType identifies a category of item that Client.Search can return.
+type SearchType string
+
+// These are the SearchTypes supported by the Search endpoint.
+const (
+	SearchTypeTrack    SearchType = "track"
+	SearchTypeAlbum    SearchType = "album"
+	SearchTypeArtist   SearchType = "artist"
+	SearchTypePlaylist SearchType = "playlist"
+	SearchTypeShow     SearchType = "show"
+	SearchTypeEpisode  SearchType = "episode"
+)
+
+// SearchRequest describes a catalog search against Client.Search.
+type SearchRequest struct {
+	// Query is the search query, using Spotify's query syntax (e.g.
+	// `artist:"Radiohead" track:"Karma Police"`).
+	Query string
+	// Types lists the categories to search; Spotify only populates the
+	// corresponding field of SearchResult for each type requested.
+	Types []SearchType
+	// Market is an ISO 3166-1 alpha-2 country code. If specified, only
+	// content playable in that market is returned.
+	Market *string
+	// Limit is the maximum number of items to return per type.
+	Limit *int
+	// Offset is the index of the first item to return per type.
+	Offset *int
+	// IncludeExternal, if true, includes externally hosted audio content
+	// that Spotify has marked as such, in addition to its own catalog.
+	IncludeExternal bool
+}
+
+// SearchResult holds the results of a Client.Search call. Only the fields
+// corresponding to the requested SearchTypes are populated; the rest are
+// nil.
+type SearchResult struct {
+	Tracks    *FullTrackPage
+	Albums    *SimpleAlbumPage
+	Artists   *FullArtistPage
+	Playlists *SimplePlaylistPage
+	Shows     *SimpleShowPage
+	Episodes  *SimpleEpisodePage
+}
+
+// Search queries Spotify's catalog for tracks, albums, artists,
+// playlists, shows, and/or episodes matching req.
+func (c *Client) Search(ctx context.Context, req SearchRequest) (*SearchResult, error) {
+	if req.Query == "" {
+		return nil, errors.New("spotify: search requires a non-empty query")
+	}
+	if len(req.Types) == 0 {
+		return nil, errors.New("spotify: search requires at least one SearchType")
+	}
+
+	types := make([]string, len(req.Types))
+	for i, t := range req.Types {
+		types[i] = string(t)
+	}
+
+	v := url.Values{}
+	v.Set("q", req.Query)
+	v.Set("type", strings.Join(types, ","))
+	if req.Market != nil {
+		v.Set("market", *req.Market)
+	}
+	if req.Limit != nil {
+		v.Set("limit", strconv.Itoa(*req.Limit))
+	}
+	if req.Offset != nil {
+		v.Set("offset", strconv.Itoa(*req.Offset))
+	}
+	if req.IncludeExternal {
+		v.Set("include_external", "audio")
+	}
+
+	var objmap map[string]*json.RawMessage
+	if err := c.get(ctx, c.baseURL+"search?"+v.Encode(), &objmap); err != nil {
+		return nil, err
+	}
+
+	var result SearchResult
+	if raw, ok := objmap["tracks"]; ok {
+		var page FullTrackPage
+		if err := json.Unmarshal(*raw, &page); err != nil {
+			return nil, err
+		}
+		result.Tracks = &page
+	}
+	if raw, ok := objmap["albums"]; ok {
+		var page SimpleAlbumPage
+		if err := json.Unmarshal(*raw, &page); err != nil {
+			return nil, err
+		}
+		result.Albums = &page
+	}
+	if raw, ok := objmap["artists"]; ok {
+		var page FullArtistPage
+		if err := json.Unmarshal(*raw, &page); err != nil {
+			return nil, err
+		}
+		result.Artists = &page
+	}
+	if raw, ok := objmap["playlists"]; ok {
+		var page SimplePlaylistPage
+		if err := json.Unmarshal(*raw, &page); err != nil {
+			return nil, err
+		}
+		result.Playlists = &page
+	}
+	if raw, ok := objmap["shows"]; ok {
+		var page SimpleShowPage
+		if err := json.Unmarshal(*raw, &page); err != nil {
+			return nil, err
+		}
+		result.Shows = &page
+	}
+	if raw, ok := objmap["episodes"]; ok {
+		var page SimpleEpisodePage
+		if err := json.Unmarshal(*raw, &page); err != nil {
+			return nil, err
+		}
+		result.Episodes = &page
+	}
+
+	return &result, nil
+}
+
+// SearchTracksAll is like Search restricted to SearchTypeTrack, but it
+// walks every page of track results, prefetching up to workers pages
+// concurrently and streaming them down the returned channel in order. See
+// Paginate for cancellation and error-handling semantics.
+func (c *Client) SearchTracksAll(ctx context.Context, req SearchRequest, workers int) <-chan PageResult[FullTrack] {
+	req.Types = []SearchType{SearchTypeTrack}
+	fail := func(err error) <-chan PageResult[FullTrack] {
+		out := make(chan PageResult[FullTrack], 1)
+		out <- PageResult[FullTrack]{Err: err}
+		close(out)
+		return out
+	}
+
+	first, err := c.Search(ctx, req)
+	if err != nil {
+		return fail(err)
+	}
+	if first.Tracks == nil {
+		return fail(errors.New("spotify: search returned no track results"))
+	}
+
+	return Paginate[FullTrack](ctx, workers, *first.Tracks, func(ctx context.Context, offset int) (FullTrackPage, error) {
+		pageReq := req
+		pageReq.Offset = &offset
+		page, err := c.Search(ctx, pageReq)
+		if err != nil {
+			return FullTrackPage{}, err
+		}
+		if page.Tracks == nil {
+			return FullTrackPage{}, errors.New("spotify: search returned no track results")
+		}
+		return *page.Tracks, nil
+	})
+}
@@ -0,0 +1,71 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(server *httptest.Server) *Client {
+	return &Client{
+		http:    server.Client(),
+		baseURL: server.URL + "/",
+		cache:   NopCache{},
+	}
+}
+
+func TestSearchDecodesRequestedTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("type"); got != "track,album" {
+			t.Errorf("type query param: got %q, want %q", got, "track,album")
+		}
+		w.Write([]byte(`{
+			"tracks": {"items": [{"name": "A Track"}], "total": 1, "limit": 20, "offset": 0},
+			"albums": {"items": [{"name": "An Album"}], "total": 1, "limit": 20, "offset": 0}
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	result, err := c.Search(context.Background(), SearchRequest{
+		Query: "foo",
+		Types: []SearchType{SearchTypeTrack, SearchTypeAlbum},
+	})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if result.Tracks == nil || len(result.Tracks.Tracks) != 1 || result.Tracks.Tracks[0].Name != "A Track" {
+		t.Fatalf("Tracks: got %+v", result.Tracks)
+	}
+	if result.Albums == nil || len(result.Albums.Albums) != 1 || result.Albums.Albums[0].Name != "An Album" {
+		t.Fatalf("Albums: got %+v", result.Albums)
+	}
+	if result.Artists != nil || result.Playlists != nil || result.Shows != nil || result.Episodes != nil {
+		t.Fatalf("expected unrequested fields to stay nil, got %+v", result)
+	}
+}
+
+func TestSearchRequiresQueryAndTypes(t *testing.T) {
+	c := &Client{}
+	if _, err := c.Search(context.Background(), SearchRequest{Types: []SearchType{SearchTypeTrack}}); err == nil {
+		t.Fatal("expected an error for an empty query")
+	}
+	if _, err := c.Search(context.Background(), SearchRequest{Query: "foo"}); err == nil {
+		t.Fatal("expected an error for no SearchTypes")
+	}
+}
+
+func TestSearchTracksAllFailsWithoutTrackResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"albums": {"items": [], "total": 0, "limit": 20, "offset": 0}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	ch := c.SearchTracksAll(context.Background(), SearchRequest{Query: "foo"}, 2)
+	res := <-ch
+	if res.Err == nil {
+		t.Fatal("expected an error when the server returns no track results")
+	}
+}
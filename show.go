@@ -0,0 +1,40 @@
+package spotify
+
+// SimpleShow contains basic information about a podcast show.
+type SimpleShow struct {
+	Name        string `json:"name"`
+	ID          ID     `json:"id"`
+	URI         URI    `json:"uri"`
+	Endpoint    string `json:"href"`
+	Publisher   string `json:"publisher"`
+	Description string `json:"description"`
+}
+
+// SimpleShowPage contains SimpleShows returned by a Spotify paging
+// endpoint, along with the paging metadata needed to walk further pages.
+type SimpleShowPage struct {
+	basePage
+	Shows []SimpleShow `json:"items"`
+}
+
+func (p SimpleShowPage) items() []SimpleShow { return p.Shows }
+
+// SimpleEpisode contains basic information about a podcast episode.
+type SimpleEpisode struct {
+	Name        string `json:"name"`
+	ID          ID     `json:"id"`
+	URI         URI    `json:"uri"`
+	Endpoint    string `json:"href"`
+	Description string `json:"description"`
+	Duration    int    `json:"duration_ms"`
+	Explicit    bool   `json:"explicit"`
+}
+
+// SimpleEpisodePage contains SimpleEpisodes returned by a Spotify paging
+// endpoint, along with the paging metadata needed to walk further pages.
+type SimpleEpisodePage struct {
+	basePage
+	Episodes []SimpleEpisode `json:"items"`
+}
+
+func (p SimpleEpisodePage) items() []SimpleEpisode { return p.Episodes }
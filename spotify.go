@@ -4,19 +4,19 @@ package spotify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/patrickmn/go-cache"
 )
 
 // Version is the version of this library.
@@ -51,6 +51,21 @@ type Client struct {
 	baseURL string
 
 	AutoRetry bool
+
+	// flow records which OAuth2 flow produced this Client, so that endpoints
+	// requiring user authorization can refuse to run against an app-only
+	// client created via NewAppClient.
+	flow authFlow
+
+	cache         ResponseCache
+	cacheObserver CacheObserver
+
+	limiter        *RateLimiter
+	rateLimitHooks []func(time.Duration)
+	rateLimitMu    sync.Mutex
+	lastRetryAfter time.Duration
+
+	slog *slog.Logger
 }
 
 // URI identifies an artist, album, track, or category.  For example,
@@ -61,13 +76,6 @@ type URI string
 // It can be found at the end of a spotify.URI.
 type ID string
 
-type cachedResponse struct {
-	Etag   string
-	Result *[]byte
-}
-
-var kaszka = cache.New(20*time.Minute, 3*time.Minute)
-
 func (id *ID) String() string {
 	return string(*id)
 }
@@ -174,7 +182,13 @@ func isFailure(code int, validCodes []int) bool {
 // status codes that will be treated as success. Note that we allow all 200s
 // even if there are additional success codes that represent success.
 func (c *Client) execute(req *http.Request, result interface{}, needsStatus ...int) error {
+	attempt := 0
 	for {
+		if c.limiter != nil {
+			if d := c.limiter.wait(req.URL.Host); d > 0 {
+				c.noteRateLimit(d)
+			}
+		}
 		resp, err := c.http.Do(req)
 		if err != nil {
 			return err
@@ -182,7 +196,10 @@ func (c *Client) execute(req *http.Request, result interface{}, needsStatus ...i
 		defer resp.Body.Close()
 
 		if c.AutoRetry && shouldRetry(resp.StatusCode) {
-			time.Sleep(retryDuration(resp))
+			d := retryDuration(resp, attempt)
+			c.noteRateLimit(d)
+			time.Sleep(d)
+			attempt++
 			continue
 		}
 		if resp.StatusCode == http.StatusNoContent {
@@ -204,37 +221,36 @@ func (c *Client) execute(req *http.Request, result interface{}, needsStatus ...i
 	return nil
 }
 
-func retryDuration(resp *http.Response) time.Duration {
-	raw := resp.Header.Get("Retry-After")
-	if raw == "" {
-		return defaultRetryDuration
-	}
-	seconds, err := strconv.ParseInt(raw, 10, 32)
-	if err != nil {
-		return defaultRetryDuration
-	}
-	return time.Duration(seconds) * time.Second
-}
-
 //
-func (c *Client) get(url string, result interface{}) error {
+func (c *Client) get(ctx context.Context, url string, result interface{}) error {
+	attempt := 0
+	start := time.Now()
+	reqID := nextRequestID()
 	for {
-		req, _ := http.NewRequest("GET", url, nil)
+		req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if c.limiter != nil {
+			if d := c.limiter.wait(req.URL.Host); d > 0 {
+				c.noteRateLimit(d)
+			}
+		}
 		var etag string
-		if k, found := kaszka.Get(url); found {
-			b := k.(*cachedResponse)
+		if b, found := c.cache.Get(url); found {
 			etag = b.Etag
 			if etag != "" {
 				req.Header.Set("If-None-Match", etag)
 			} else {
-				body := ioutil.NopCloser(bytes.NewBuffer(*b.Result))
+				body := ioutil.NopCloser(bytes.NewBuffer(b.Result))
 				err := json.NewDecoder(body).Decode(result)
 				if err != nil {
 					return err
 				}
-				log.Println("spotify: using cached response")
+				c.observeCache(CacheHit, url)
+				c.logger().Info("spotify: request", "method", "GET", "url", url, "cache", "hit",
+					"duration_ms", time.Since(start).Milliseconds(), "request_id", reqID)
 				break
 			}
+		} else {
+			c.observeCache(CacheMiss, url)
 		}
 		resp, err := c.http.Do(req)
 		if err != nil {
@@ -242,7 +258,12 @@ func (c *Client) get(url string, result interface{}) error {
 		}
 		// defer resp.Body.Close()
 		if resp.StatusCode == rateLimitExceededStatusCode && c.AutoRetry {
-			time.Sleep(retryDuration(resp))
+			d := retryDuration(resp, attempt)
+			c.noteRateLimit(d)
+			c.logger().Warn("spotify: rate limited", "method", "GET", "url", url, "status", resp.StatusCode,
+				"retry_after", d.String(), "request_id", reqID)
+			time.Sleep(d)
+			attempt++
 			continue
 		}
 		if resp.StatusCode == http.StatusNoContent {
@@ -252,20 +273,19 @@ func (c *Client) get(url string, result interface{}) error {
 			return c.decodeError(resp)
 		}
 		if resp.StatusCode == http.StatusNotModified {
-			log.Printf("spotify: response: %d", resp.StatusCode)
-			if k, found := kaszka.Get(url); found {
-				b := k.(*cachedResponse)
-				resp.Body = ioutil.NopCloser(bytes.NewBuffer(*b.Result))
+			if b, found := c.cache.Get(url); found {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b.Result))
 				err = json.NewDecoder(resp.Body).Decode(result)
 				if err != nil {
 					return err
 				}
 			}
-			log.Println("spotify: using ETag response")
+			c.observeCache(CacheNotModified, url)
+			c.logger().Info("spotify: request", "method", "GET", "url", url, "status", resp.StatusCode, "cache", "304",
+				"duration_ms", time.Since(start).Milliseconds(), "request_id", reqID)
 			break
 		}
 		if resp.StatusCode == http.StatusOK {
-			log.Printf("spotify: response: %d", resp.StatusCode)
 			bodyBytes, _ := ioutil.ReadAll(resp.Body)
 			resp.Body.Close() //  must close
 			resp.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
@@ -273,10 +293,19 @@ func (c *Client) get(url string, result interface{}) error {
 			if err != nil {
 				return err
 			}
-			// log.Printf("result: %v", result)
 			resp.Body.Close() //  must close to reuse
 			resp.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
-			cacheResponse(resp, url, &bodyBytes) // cache response body
+			c.cacheResponse(resp, url, bodyBytes) // cache response body
+			cacheState := "miss"
+			if etag != "" {
+				// We had a cached entry and sent If-None-Match, but the
+				// server rejected it and sent a fresh body instead of a
+				// 304: the cached copy was stale, not just absent.
+				c.observeCache(CacheStale, url)
+				cacheState = "stale"
+			}
+			c.logger().Info("spotify: request", "method", "GET", "url", url, "status", resp.StatusCode, "cache", cacheState,
+				"duration_ms", time.Since(start).Milliseconds(), "request_id", reqID)
 			break
 		}
 
@@ -288,10 +317,9 @@ func (c *Client) get(url string, result interface{}) error {
 Cache-Control and Etag (Spotify is using one or the other)
 Response is cached until expiration.
 */
-func cacheResponse(res *http.Response, url string, body *[]byte) {
-	var cR cachedResponse
+func (c *Client) cacheResponse(res *http.Response, url string, body []byte) {
+	var cR CachedResponse
 	cc := res.Header.Get("Cache-Control")
-	log.Printf("spotify: Cache-Control: %s", cc)
 	var cci int
 	if cc != "" {
 		i := strings.Index(cc, "max-age=")
@@ -311,13 +339,10 @@ func cacheResponse(res *http.Response, url string, body *[]byte) {
 	var expires string
 	if cci == 0 {
 		expires = res.Header.Get("Expires")
-		log.Printf("spotify: Expires: %s", res.Header.Get("Expires"))
 	}
 	iee := cci == 0 && isEmptyExpires(expires)
 	lm := res.Header.Get("Last-Modified")
 	et := res.Header.Get("ETag")
-	log.Printf("spotify: Last-Modified: %s", lm)
-	log.Printf("spotify: ETag: %s", et)
 	if lm == "" && et == "" && iee {
 		return
 	}
@@ -333,17 +358,17 @@ func cacheResponse(res *http.Response, url string, body *[]byte) {
 			}
 		}
 	}
-	log.Printf("Expires: %s", duration(ed))
+	c.logger().Debug("spotify: cache-control", "url", url, "cache_control", cc, "expires", expires,
+		"etag", et, "last_modified", lm, "ttl", duration(ed))
 	if et != "" {
 		cR.Etag = et
 	} else {
 		cR.Etag = ""
 		// cR.Etag = etag.Generate(string(*body), false) // If Spotify have not provided ETag make it yourself
-		// log.Printf("Etag: %s", cR.Etag)
 	}
 	cR.Result = body
 	if ed > 0.0 {
-		kaszka.Set(url, &cR, ed)
+		c.cache.Set(url, &cR, ed)
 	}
 	return
 }
@@ -393,7 +418,7 @@ type Options struct {
 
 // NewReleasesOpt is like NewReleases, but it accepts optional parameters
 // for filtering the results.
-func (c *Client) NewReleasesOpt(opt *Options) (albums *SimpleAlbumPage, err error) {
+func (c *Client) NewReleasesOpt(ctx context.Context, opt *Options) (albums *SimpleAlbumPage, err error) {
 	spotifyURL := c.baseURL + "browse/new-releases"
 	if opt != nil {
 		v := url.Values{}
@@ -412,7 +437,7 @@ func (c *Client) NewReleasesOpt(opt *Options) (albums *SimpleAlbumPage, err erro
 	}
 
 	var objmap map[string]*json.RawMessage
-	err = c.get(spotifyURL, &objmap)
+	err = c.get(ctx, spotifyURL, &objmap)
 	if err != nil {
 		return nil, err
 	}
@@ -429,7 +454,41 @@ func (c *Client) NewReleasesOpt(opt *Options) (albums *SimpleAlbumPage, err erro
 // NewReleases gets a list of new album releases featured in Spotify.
 // This call requires bearer authorization.
 func (c *Client) NewReleases() (albums *SimpleAlbumPage, err error) {
-	return c.NewReleasesOpt(nil)
+	return c.NewReleasesOpt(context.Background(), nil)
+}
+
+// NewReleasesAll is like NewReleasesOpt, but it walks every page of
+// results instead of just the one opt.Offset/opt.Limit selects,
+// prefetching up to workers pages concurrently and streaming them down
+// the returned channel in order. See Paginate for cancellation and
+// error-handling semantics.
+func (c *Client) NewReleasesAll(ctx context.Context, opt *Options, workers int) <-chan PageResult[SimpleAlbum] {
+	first, err := c.NewReleasesOpt(ctx, opt)
+	if err != nil {
+		out := make(chan PageResult[SimpleAlbum], 1)
+		out <- PageResult[SimpleAlbum]{Err: err}
+		close(out)
+		return out
+	}
+	return Paginate[SimpleAlbum](ctx, workers, *first, func(ctx context.Context, offset int) (SimpleAlbumPage, error) {
+		page, err := c.NewReleasesOpt(ctx, cloneOptionsAt(opt, offset))
+		if err != nil {
+			return SimpleAlbumPage{}, err
+		}
+		return *page, nil
+	})
+}
+
+// cloneOptionsAt copies opt (or a zero Options if opt is nil) with Offset
+// overridden, so pagination helpers can fetch an arbitrary page without
+// mutating the caller's Options.
+func cloneOptionsAt(opt *Options, offset int) *Options {
+	var o Options
+	if opt != nil {
+		o = *opt
+	}
+	o.Offset = &offset
+	return &o
 }
 
 func isEmptyExpires(expires string) bool {
@@ -0,0 +1,18 @@
+package spotify
+
+// FullTrack provides extra track data on top of SimpleTrack, such as its
+// album and popularity.
+type FullTrack struct {
+	SimpleTrack
+	Album      SimpleAlbum `json:"album"`
+	Popularity int         `json:"popularity"`
+}
+
+// FullTrackPage contains FullTracks returned by a Spotify paging
+// endpoint, along with the paging metadata needed to walk further pages.
+type FullTrackPage struct {
+	basePage
+	Tracks []FullTrack `json:"items"`
+}
+
+func (p FullTrackPage) items() []FullTrack { return p.Tracks }